@@ -0,0 +1,139 @@
+package appdetect
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// AzureFunctions describes an Azure Functions project detected within a service directory.
+type AzureFunctions struct {
+	// Runtime is the detected Functions worker runtime, e.g. "node", "python", "dotnet-isolated", "java".
+	Runtime string
+}
+
+// skippedFunctionsDetectDirs are directories that are never worth descending into while looking for Functions
+// source markers; they're either dependency trees or build output.
+var skippedFunctionsDetectDirs = map[string]bool{
+	"node_modules": true,
+	"bin":          true,
+	"obj":          true,
+	".git":         true,
+	".venv":        true,
+	"__pycache__":  true,
+}
+
+var (
+	dotNetFunctionAttributeRegex = regexp.MustCompile(`\[\s*Function(?:Name)?\s*\(`)
+	javaFunctionAnnotationRegex  = regexp.MustCompile(`@FunctionName\s*\(`)
+)
+
+// DetectAzureFunctions inspects a service directory for signs of an Azure Functions project and returns metadata
+// about it, or nil if the directory doesn't look like a Functions app.
+//
+// host.json is present at the root of every Functions app regardless of worker runtime, so its presence alone only
+// tells us we're looking at a Functions app, not which runtime it uses. Once host.json is found, the worker runtime
+// is narrowed by, in order:
+//   - package.json or requirements.txt alongside it (Node.js / Python function apps)
+//   - a function.json in an immediate subdirectory (classic Node.js/Python function folder layout)
+//   - a `[Function("...")]` / `[FunctionName("...")]` attribute in a .cs file (.NET in-process/isolated worker)
+//   - an `@FunctionName(...)` annotation in a .java file (Java worker)
+//
+// If host.json isn't present at all, the directory isn't a Functions app.
+func DetectAzureFunctions(projectDir string) (*AzureFunctions, error) {
+	if _, err := os.Stat(filepath.Join(projectDir, "host.json")); os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if runtime, ok, err := functionsRuntimeFromProjectFiles(projectDir); err != nil {
+		return nil, err
+	} else if ok {
+		return &AzureFunctions{Runtime: runtime}, nil
+	}
+
+	return &AzureFunctions{Runtime: "custom"}, nil
+}
+
+// functionsRuntimeFromProjectFiles narrows the Functions worker runtime for a project that already has a host.json,
+// based on the project files present alongside it. ok is false if none of the known markers matched, in which case
+// the caller falls back to runtime "custom".
+func functionsRuntimeFromProjectFiles(projectDir string) (runtime string, ok bool, err error) {
+	if _, err := os.Stat(filepath.Join(projectDir, "package.json")); err == nil {
+		return "node", true, nil
+	}
+
+	if _, err := os.Stat(filepath.Join(projectDir, "requirements.txt")); err == nil {
+		return "python", true, nil
+	}
+
+	entries, err := os.ReadDir(projectDir)
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		if _, err := os.Stat(filepath.Join(projectDir, entry.Name(), "function.json")); err == nil {
+			return "node", true, nil
+		}
+	}
+
+	if found, err := dirContainsMatch(projectDir, ".cs", dotNetFunctionAttributeRegex); err != nil {
+		return "", false, err
+	} else if found {
+		return "dotnet-isolated", true, nil
+	}
+
+	if found, err := dirContainsMatch(projectDir, ".java", javaFunctionAnnotationRegex); err != nil {
+		return "", false, err
+	} else if found {
+		return "java", true, nil
+	}
+
+	return "", false, nil
+}
+
+// dirContainsMatch walks dir looking for a file with the given extension whose contents match re, skipping
+// directories in skippedFunctionsDetectDirs.
+func dirContainsMatch(dir string, ext string, re *regexp.Regexp) (bool, error) {
+	found := false
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != dir && skippedFunctionsDetectDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if found || filepath.Ext(path) != ext {
+			return nil
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if re.Match(contents) {
+			found = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
@@ -0,0 +1,180 @@
+package appdetect
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectAzureFunctions_HostJsonNode(t *testing.T) {
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "host.json"), "{}")
+	write(t, filepath.Join(dir, "package.json"), `{"name": "func"}`)
+
+	result, err := DetectAzureFunctions(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result == nil {
+		t.Fatal("expected Functions project to be detected")
+	}
+	if result.Runtime != "node" {
+		t.Errorf("expected runtime 'node', got %q", result.Runtime)
+	}
+}
+
+func TestDetectAzureFunctions_HostJsonPython(t *testing.T) {
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "host.json"), "{}")
+	write(t, filepath.Join(dir, "requirements.txt"), "azure-functions")
+
+	result, err := DetectAzureFunctions(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result == nil || result.Runtime != "python" {
+		t.Fatalf("expected runtime 'python', got %+v", result)
+	}
+}
+
+func TestDetectAzureFunctions_FunctionJsonFolder(t *testing.T) {
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "host.json"), "{}")
+	write(t, filepath.Join(dir, "HttpTrigger", "function.json"), "{}")
+
+	result, err := DetectAzureFunctions(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result == nil || result.Runtime != "node" {
+		t.Fatalf("expected runtime 'node', got %+v", result)
+	}
+}
+
+func TestDetectAzureFunctions_DotNetAttribute(t *testing.T) {
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "host.json"), "{}")
+	write(t, filepath.Join(dir, "HttpTrigger.cs"), `
+		public class HttpTrigger {
+			[Function("HttpTrigger")]
+			public void Run() {}
+		}
+	`)
+
+	result, err := DetectAzureFunctions(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result == nil || result.Runtime != "dotnet-isolated" {
+		t.Fatalf("expected runtime 'dotnet-isolated', got %+v", result)
+	}
+}
+
+func TestDetectAzureFunctions_JavaAnnotation(t *testing.T) {
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "host.json"), "{}")
+	write(t, filepath.Join(dir, "Function.java"), `
+		public class Function {
+			@FunctionName("HttpTrigger")
+			public void run() {}
+		}
+	`)
+
+	result, err := DetectAzureFunctions(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result == nil || result.Runtime != "java" {
+		t.Fatalf("expected runtime 'java', got %+v", result)
+	}
+}
+
+func TestDetectAzureFunctions_HostJsonWithDotNetAttribute(t *testing.T) {
+	// A .NET isolated-worker Functions app has a host.json at its root just like every other worker runtime; make
+	// sure that doesn't short-circuit detection to "custom" before the .cs scan ever runs.
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "host.json"), "{}")
+	write(t, filepath.Join(dir, "HttpTrigger.cs"), `
+		public class HttpTrigger {
+			[Function("HttpTrigger")]
+			public void Run() {}
+		}
+	`)
+
+	result, err := DetectAzureFunctions(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result == nil || result.Runtime != "dotnet-isolated" {
+		t.Fatalf("expected runtime 'dotnet-isolated', got %+v", result)
+	}
+}
+
+func TestDetectAzureFunctions_HostJsonWithJavaAnnotation(t *testing.T) {
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "host.json"), "{}")
+	write(t, filepath.Join(dir, "Function.java"), `
+		public class Function {
+			@FunctionName("HttpTrigger")
+			public void run() {}
+		}
+	`)
+
+	result, err := DetectAzureFunctions(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result == nil || result.Runtime != "java" {
+		t.Fatalf("expected runtime 'java', got %+v", result)
+	}
+}
+
+func TestDetectAzureFunctions_HostJsonOnly(t *testing.T) {
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "host.json"), "{}")
+
+	result, err := DetectAzureFunctions(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result == nil || result.Runtime != "custom" {
+		t.Fatalf("expected runtime 'custom', got %+v", result)
+	}
+}
+
+func TestDetectAzureFunctions_NotAFunctionsProject(t *testing.T) {
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "server.js"), "console.log('hello')")
+
+	result, err := DetectAzureFunctions(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != nil {
+		t.Fatalf("expected no Functions project to be detected, got %+v", result)
+	}
+}
+
+func TestDetectAzureFunctions_SkipsNodeModules(t *testing.T) {
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "host.json"), "{}")
+	write(t, filepath.Join(dir, "node_modules", "some-dep", "Function.java"), `@FunctionName("x")`)
+
+	result, err := DetectAzureFunctions(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result == nil || result.Runtime != "custom" {
+		t.Fatalf("expected matches under node_modules to be ignored (runtime 'custom'), got %+v", result)
+	}
+}
+
+func write(t *testing.T, path string, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/azure/azure-dev/cli/azd/internal/appdetect"
+	"gopkg.in/yaml.v3"
+)
+
+// ServiceDetectConfig holds per-service overrides for values azd would otherwise prompt for during `azd init`
+// detection. Users set these in the sidecar file .azd/detect.yaml keyed by service name, mirroring the existing
+// pattern of per-service annotations overriding detection defaults; azure.yaml is not read for them. Any field left
+// unset is prompted for as before.
+type ServiceDetectConfig struct {
+	// Port pins the port the service listens on, skipping PromptPort.
+	Port *int `yaml:"port,omitempty"`
+	// Db pins database/cache names by kind (e.g. "postgres", "mysql", "redis"), skipping the name (and, for
+	// redis, SKU) prompts for that kind.
+	Db map[string]string `yaml:"db,omitempty"`
+	// Frontend pins the frontend-to-backend wiring azd would otherwise infer automatically.
+	Frontend *ServiceDetectFrontendConfig `yaml:"frontend,omitempty"`
+}
+
+// ServiceDetectFrontendConfig pins the backends a frontend service talks to.
+type ServiceDetectFrontendConfig struct {
+	// Backends lists the service names this frontend talks to.
+	Backends []string `yaml:"backends,omitempty"`
+}
+
+// detectConfigFileName is the sidecar file, under the project's .azd directory, that holds the overrides.
+const detectConfigFileName = "detect.yaml"
+
+// dbDetectConfigKeys maps an appdetect database kind to the key used under a service's `detect.db` block,
+// e.g. `db: {postgres: appdb}`.
+var dbDetectConfigKeys = map[appdetect.DatabaseDep]string{
+	appdetect.DbMongo:    "mongo",
+	appdetect.DbPostgres: "postgres",
+	appdetect.DbMySql:    "mysql",
+	appdetect.DbRedis:    "redis",
+}
+
+// loadDetectOverrides reads per-service detection overrides for the project rooted at projectDir from
+// .azd/detect.yaml. A missing file is not an error; callers get an empty map and fall back to prompting as before.
+func loadDetectOverrides(projectDir string) (map[string]ServiceDetectConfig, error) {
+	path := filepath.Join(projectDir, ".azd", detectConfigFileName)
+
+	contents, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]ServiceDetectConfig{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var overrides map[string]ServiceDetectConfig
+	if err := yaml.Unmarshal(contents, &overrides); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	if overrides == nil {
+		overrides = map[string]ServiceDetectConfig{}
+	}
+
+	return overrides, nil
+}
+
+// dbNameOverride returns the pinned database name for the given database kind, and whether one was found. If more
+// than one service pins a name for the same kind, the service that sorts first by name wins; this is arbitrary in
+// the same way it would be arbitrary if two services genuinely disagreed, but it's deterministic across runs,
+// unlike ranging over the overrides map directly.
+func dbNameOverride(overrides map[string]ServiceDetectConfig, database appdetect.DatabaseDep) (string, bool) {
+	key, ok := dbDetectConfigKeys[database]
+	if !ok {
+		return "", false
+	}
+
+	serviceNames := make([]string, 0, len(overrides))
+	for serviceName := range overrides {
+		serviceNames = append(serviceNames, serviceName)
+	}
+	sort.Strings(serviceNames)
+
+	for _, serviceName := range serviceNames {
+		if name, ok := overrides[serviceName].Db[key]; ok {
+			return name, true
+		}
+	}
+
+	return "", false
+}
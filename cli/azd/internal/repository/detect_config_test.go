@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/internal/appdetect"
+)
+
+func TestLoadDetectOverrides_MissingFile(t *testing.T) {
+	overrides, err := loadDetectOverrides(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(overrides) != 0 {
+		t.Errorf("expected no overrides for a missing file, got %+v", overrides)
+	}
+}
+
+func TestLoadDetectOverrides_ParsesServiceBlocks(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".azd"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	contents := `
+api:
+  port: 5000
+  db:
+    postgres: appdb
+web:
+  frontend:
+    backends: [api]
+`
+	if err := os.WriteFile(filepath.Join(dir, ".azd", "detect.yaml"), []byte(contents), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	overrides, err := loadDetectOverrides(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	api, ok := overrides["api"]
+	if !ok {
+		t.Fatal("expected an override for service 'api'")
+	}
+	if api.Port == nil || *api.Port != 5000 {
+		t.Errorf("api.Port = %v, want 5000", api.Port)
+	}
+	if api.Db["postgres"] != "appdb" {
+		t.Errorf("api.Db[postgres] = %q, want %q", api.Db["postgres"], "appdb")
+	}
+
+	web, ok := overrides["web"]
+	if !ok {
+		t.Fatal("expected an override for service 'web'")
+	}
+	if web.Frontend == nil || len(web.Frontend.Backends) != 1 || web.Frontend.Backends[0] != "api" {
+		t.Errorf("web.Frontend.Backends = %+v, want [api]", web.Frontend)
+	}
+}
+
+func TestDbNameOverride_DeterministicAcrossServices(t *testing.T) {
+	overrides := map[string]ServiceDetectConfig{
+		"zeta":  {Db: map[string]string{"postgres": "from-zeta"}},
+		"alpha": {Db: map[string]string{"postgres": "from-alpha"}},
+	}
+
+	// Regardless of map iteration order, the lexicographically first service name ("alpha") should always win.
+	for i := 0; i < 20; i++ {
+		name, ok := dbNameOverride(overrides, appdetect.DbPostgres)
+		if !ok {
+			t.Fatal("expected an override to be found")
+		}
+		if name != "from-alpha" {
+			t.Fatalf("dbNameOverride = %q, want deterministic %q", name, "from-alpha")
+		}
+	}
+}
+
+func TestDbNameOverride_RedisSupported(t *testing.T) {
+	overrides := map[string]ServiceDetectConfig{
+		"cache": {Db: map[string]string{"redis": "my-cache"}},
+	}
+
+	name, ok := dbNameOverride(overrides, appdetect.DbRedis)
+	if !ok || name != "my-cache" {
+		t.Fatalf("dbNameOverride(redis) = (%q, %v), want (\"my-cache\", true)", name, ok)
+	}
+}
+
+func TestDbNameOverride_NoOverride(t *testing.T) {
+	overrides := map[string]ServiceDetectConfig{
+		"api": {Db: map[string]string{"mysql": "appdb"}},
+	}
+
+	_, ok := dbNameOverride(overrides, appdetect.DbPostgres)
+	if ok {
+		t.Fatal("expected no override for a database kind nobody pinned")
+	}
+}
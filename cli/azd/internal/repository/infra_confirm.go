@@ -23,11 +23,34 @@ var wellFormedDbNameRegex = regexp.MustCompile(`^[a-zA-Z\-_0-9]*$`)
 func (i *Initializer) infraSpecFromDetect(
 	ctx context.Context,
 	detect detectConfirm) (scaffold.InfraSpec, error) {
+	overrides, err := loadDetectOverrides(i.azdCtx.ProjectDirectory())
+	if err != nil {
+		return scaffold.InfraSpec{}, err
+	}
+
 	spec := scaffold.InfraSpec{}
 	for database := range detect.Databases {
+		if dbName, ok := dbNameOverride(overrides, database); ok {
+			switch database {
+			case appdetect.DbMongo:
+				spec.DbCosmosMongo = &scaffold.DatabaseCosmosMongo{DatabaseName: dbName}
+			case appdetect.DbPostgres:
+				spec.DbPostgres = &scaffold.DatabasePostgres{DatabaseName: dbName}
+			case appdetect.DbMySql:
+				spec.DbMySql = &scaffold.DatabaseMySql{DatabaseName: dbName}
+			case appdetect.DbRedis:
+				spec.DbRedis = redisSpecFromOverride(dbName)
+			}
+			continue
+		}
+
 		if database == appdetect.DbRedis {
-			spec.DbRedis = &scaffold.DatabaseRedis{}
-			// no further configuration needed for redis
+			redisSpec, err := promptRedisSpec(i.console, ctx)
+			if err != nil {
+				return scaffold.InfraSpec{}, err
+			}
+
+			spec.DbRedis = redisSpec
 			continue
 		}
 
@@ -53,6 +76,15 @@ func (i *Initializer) infraSpecFromDetect(
 				spec.DbPostgres = &scaffold.DatabasePostgres{
 					DatabaseName: dbName,
 				}
+			case appdetect.DbMySql:
+				if dbName == "" {
+					i.console.Message(ctx, "Database name is required.")
+					continue
+				}
+
+				spec.DbMySql = &scaffold.DatabaseMySql{
+					DatabaseName: dbName,
+				}
 			}
 			break dbPrompt
 		}
@@ -65,11 +97,20 @@ func (i *Initializer) infraSpecFromDetect(
 			Port: -1,
 		}
 
-		port, err := PromptPort(i.console, ctx, name, svc)
+		override := overrides[name]
+
+		isFunctionApp, runtime, port, err := resolveServicePort(ctx, i.console, name, svc, override)
 		if err != nil {
 			return scaffold.InfraSpec{}, err
 		}
-		serviceSpec.Port = port
+
+		if isFunctionApp {
+			// Function apps are triggered, not listened on; there's no port to prompt for.
+			serviceSpec.Host = scaffold.HostFunctionApp
+			serviceSpec.AzureFunctions = &scaffold.AzureFunctions{Runtime: runtime}
+		} else {
+			serviceSpec.Port = port
+		}
 
 		for _, framework := range svc.Dependencies {
 			if framework.IsWebUIFramework() {
@@ -92,9 +133,14 @@ func (i *Initializer) infraSpecFromDetect(
 				serviceSpec.DbPostgres = &scaffold.DatabaseReference{
 					DatabaseName: spec.DbPostgres.DatabaseName,
 				}
+			case appdetect.DbMySql:
+				serviceSpec.DbMySql = &scaffold.DatabaseReference{
+					DatabaseName: spec.DbMySql.DatabaseName,
+				}
 			case appdetect.DbRedis:
 				serviceSpec.DbRedis = &scaffold.DatabaseReference{
-					DatabaseName: "redis",
+					DatabaseName: spec.DbRedis.DatabaseName,
+					AuthType:     spec.DbRedis.AuthType,
 				}
 			}
 		}
@@ -119,8 +165,16 @@ func (i *Initializer) infraSpecFromDetect(
 
 	// Link services together
 	for _, service := range spec.Services {
-		if service.Frontend != nil && len(backends) > 0 {
-			service.Frontend.Backends = backends
+		if service.Frontend != nil {
+			if override := overrides[service.Name]; override.Frontend != nil && len(override.Frontend.Backends) > 0 {
+				pinnedBackends := make([]scaffold.ServiceReference, len(override.Frontend.Backends))
+				for idx, name := range override.Frontend.Backends {
+					pinnedBackends[idx] = scaffold.ServiceReference{Name: name}
+				}
+				service.Frontend.Backends = pinnedBackends
+			} else if len(backends) > 0 {
+				service.Frontend.Backends = backends
+			}
 		}
 
 		if service.Backend != nil && len(frontends) > 0 {
@@ -205,6 +259,126 @@ func promptDbName(console input.Console, ctx context.Context, database appdetect
 	}
 }
 
+// redisSkuOptions are the Azure Cache for Redis pricing tiers offered during scaffolding, in the order they should
+// be presented to the user.
+var redisSkuOptions = []string{"Basic", "Standard", "Premium"}
+
+// promptCacheName prompts for the name of the Azure Cache for Redis instance to provision.
+func promptCacheName(console input.Console, ctx context.Context) (string, error) {
+	for {
+		cacheName, err := console.Prompt(ctx, input.ConsoleOptions{
+			Message: "Input the name of the app cache (redis)",
+			Help: "Hint: App cache name\n\n" +
+				"Name of the Azure Cache for Redis instance that the app connects to. " +
+				"This cache will be created after running azd provision or azd up.",
+		})
+		if err != nil {
+			return "", err
+		}
+
+		if cacheName == "" {
+			console.Message(ctx, "Cache name is required.")
+			continue
+		}
+
+		if !wellFormedDbNameRegex.MatchString(cacheName) {
+			console.MessageUxItem(ctx, &ux.WarningMessage{
+				Description: "Cache name contains special characters. This might not be allowed by the cache service.",
+			})
+			confirm, err := console.Confirm(ctx, input.ConsoleOptions{
+				Message: fmt.Sprintf("Continue with name '%s'?", cacheName),
+			})
+			if err != nil {
+				return "", err
+			}
+
+			if !confirm {
+				continue
+			}
+		}
+
+		return cacheName, nil
+	}
+}
+
+// promptCacheSku prompts for the Azure Cache for Redis pricing tier to provision.
+func promptCacheSku(console input.Console, ctx context.Context) (string, error) {
+	selection, err := console.Select(ctx, input.ConsoleOptions{
+		Message:      "Select the Azure Cache for Redis pricing tier to use:",
+		Options:      redisSkuOptions,
+		DefaultValue: redisSkuOptions[0],
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return redisSkuOptions[selection], nil
+}
+
+// redisAuthOptions are the authentication choices offered for Standard/Premium caches, in the order presented.
+var redisAuthOptions = []string{
+	"Key Vault (store the primary access key as a secret)",
+	"Microsoft Entra ID (token auth, no key to manage)",
+}
+
+// promptCacheAuthType prompts for how services should authenticate to the Azure Cache for Redis instance. Only
+// called for Standard/Premium tiers; Basic always uses scaffold.RedisAuthTypeKey since it doesn't support AAD auth.
+func promptCacheAuthType(console input.Console, ctx context.Context) (scaffold.RedisAuthType, error) {
+	selection, err := console.Select(ctx, input.ConsoleOptions{
+		Message:      "How should services authenticate to the cache?",
+		Options:      redisAuthOptions,
+		DefaultValue: redisAuthOptions[0],
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if selection == 1 {
+		return scaffold.RedisAuthTypeAad, nil
+	}
+
+	return scaffold.RedisAuthTypeKey, nil
+}
+
+// redisSpecFromOverride builds the DatabaseRedis spec for a pinned cache name, skipping the name and SKU prompts
+// entirely. The SKU defaults to Basic (and therefore key-based auth) since there's no `detect` field for it yet.
+func redisSpecFromOverride(dbName string) *scaffold.DatabaseRedis {
+	return &scaffold.DatabaseRedis{
+		DatabaseName: dbName,
+		SkuName:      redisSkuOptions[0],
+		AuthType:     scaffold.RedisAuthTypeKey,
+	}
+}
+
+// promptRedisSpec prompts for a cache name, SKU, and - for Standard/Premium tiers - auth type, building the
+// resulting DatabaseRedis spec. AAD token auth is only available on Standard/Premium; Basic caches fall back to the
+// access key stored in Key Vault.
+func promptRedisSpec(console input.Console, ctx context.Context) (*scaffold.DatabaseRedis, error) {
+	cacheName, err := promptCacheName(console, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	skuName, err := promptCacheSku(console, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	authType := scaffold.RedisAuthTypeKey
+	if skuName != "Basic" {
+		authType, err = promptCacheAuthType(console, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &scaffold.DatabaseRedis{
+		DatabaseName: cacheName,
+		SkuName:      skuName,
+		AuthType:     authType,
+	}, nil
+}
+
 // PromptPort prompts for port selection from an appdetect project.
 func PromptPort(
 	console input.Console,
@@ -258,3 +432,26 @@ func PromptPort(
 
 	return port, nil
 }
+
+// resolveServicePort decides whether svc should be scaffolded as a triggered Azure Functions app, which has no port
+// to prompt for, or a service that listens on a port, resolved from a pinned override or, failing that, by
+// prompting. isFunctionApp reports which case applies; runtime is only meaningful when isFunctionApp is true, and
+// port is only meaningful when it's false.
+func resolveServicePort(
+	ctx context.Context,
+	console input.Console,
+	name string,
+	svc appdetect.Project,
+	override ServiceDetectConfig,
+) (isFunctionApp bool, runtime string, port int, err error) {
+	if svc.AzureFunctions != nil {
+		return true, svc.AzureFunctions.Runtime, -1, nil
+	}
+
+	if override.Port != nil {
+		return false, "", *override.Port, nil
+	}
+
+	port, err = PromptPort(console, ctx, name, svc)
+	return false, "", port, err
+}
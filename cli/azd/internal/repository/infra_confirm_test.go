@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/internal/appdetect"
+	"github.com/azure/azure-dev/cli/azd/internal/scaffold"
+)
+
+// TestResolveServicePort_FunctionAppSkipsPortPrompt asserts that a service detected as an Azure Functions app is
+// scaffolded as HostFunctionApp and never reaches PromptPort. The project has no Dockerfile, so a fall-through to
+// PromptPort would return a default port without touching the nil console; the isFunctionApp assertion catches it.
+func TestResolveServicePort_FunctionAppSkipsPortPrompt(t *testing.T) {
+	svc := appdetect.Project{
+		AzureFunctions: &appdetect.AzureFunctions{Runtime: "node"},
+	}
+
+	isFunctionApp, runtime, _, err := resolveServicePort(context.Background(), nil, "api", svc, ServiceDetectConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !isFunctionApp {
+		t.Fatal("expected isFunctionApp = true for a detected Azure Functions project")
+	}
+	if runtime != "node" {
+		t.Errorf("runtime = %q, want %q", runtime, "node")
+	}
+}
+
+// TestResolveServicePort_OverrideSkipsPortPrompt asserts that a pinned port override is honored without ever
+// prompting. A fall-through to PromptPort would return its default of 80 for this project, which the port
+// assertion catches.
+func TestResolveServicePort_OverrideSkipsPortPrompt(t *testing.T) {
+	pinnedPort := 8080
+	override := ServiceDetectConfig{Port: &pinnedPort}
+
+	isFunctionApp, _, port, err := resolveServicePort(context.Background(), nil, "api", appdetect.Project{}, override)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if isFunctionApp {
+		t.Fatal("expected isFunctionApp = false when a port override is pinned")
+	}
+	if port != pinnedPort {
+		t.Errorf("port = %d, want %d", port, pinnedPort)
+	}
+}
+
+// TestRedisSpecFromOverride_SkipsPrompts asserts that a pinned cache name resolves straight to a Basic/key-auth
+// spec without any console interaction.
+func TestRedisSpecFromOverride_SkipsPrompts(t *testing.T) {
+	spec := redisSpecFromOverride("my-cache")
+
+	if spec.DatabaseName != "my-cache" {
+		t.Errorf("DatabaseName = %q, want %q", spec.DatabaseName, "my-cache")
+	}
+	if spec.SkuName != redisSkuOptions[0] {
+		t.Errorf("SkuName = %q, want %q", spec.SkuName, redisSkuOptions[0])
+	}
+	if spec.AuthType != scaffold.RedisAuthTypeKey {
+		t.Errorf("AuthType = %q, want %q", spec.AuthType, scaffold.RedisAuthTypeKey)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// azureProfileSubscription is a single entry in the "subscriptions" array of ~/.azure/azureProfile.json, the file
+// written by `az login` / `az account set`. Only the fields azd cares about are modeled here.
+type azureProfileSubscription struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	TenantID        string `json:"tenantId"`
+	IsDefault       bool   `json:"isDefault"`
+	EnvironmentName string `json:"environmentName"`
+}
+
+type azureProfile struct {
+	Subscriptions []azureProfileSubscription `json:"subscriptions"`
+}
+
+// azureCloudArmEndpointSuffix maps the `environmentName` values az CLI writes to azureProfile.json to the ARM
+// endpoint of that Azure cloud. The endpoint is only reported to the user; azd does not switch the ARM endpoint it
+// talks to based on it.
+var azureCloudArmEndpointSuffix = map[string]string{
+	"AzureCloud":        "management.azure.com",
+	"AzureUSGovernment": "management.usgovcloudapi.net",
+	"AzureChinaCloud":   "management.chinacloudapi.cn",
+}
+
+// defaultAzureProfileSubscription returns the subscription marked `isDefault` in the current user's
+// ~/.azure/azureProfile.json, along with the ARM endpoint suffix implied by its environmentName. ok is false if the
+// file doesn't exist, can't be parsed, or has no default subscription, in which case azd should fall back to its own
+// configuration rather than treating this as an error.
+func defaultAzureProfileSubscription() (subscription azureProfileSubscription, armEndpointSuffix string, ok bool) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return azureProfileSubscription{}, "", false
+	}
+
+	return parseDefaultAzureProfileSubscription(filepath.Join(home, ".azure", "azureProfile.json"))
+}
+
+// parseDefaultAzureProfileSubscription is the testable core of defaultAzureProfileSubscription: it reads and
+// parses the azureProfile.json at the given path instead of always resolving it from the user's home directory.
+func parseDefaultAzureProfileSubscription(path string) (subscription azureProfileSubscription, armEndpointSuffix string, ok bool) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return azureProfileSubscription{}, "", false
+	}
+
+	// az CLI writes this file with a UTF-8 BOM.
+	contents = []byte(strings.TrimPrefix(string(contents), "\ufeff"))
+
+	var profile azureProfile
+	if err := json.Unmarshal(contents, &profile); err != nil {
+		return azureProfileSubscription{}, "", false
+	}
+
+	for _, sub := range profile.Subscriptions {
+		if sub.IsDefault {
+			return sub, azureCloudArmEndpointSuffix[sub.EnvironmentName], true
+		}
+	}
+
+	return azureProfileSubscription{}, "", false
+}
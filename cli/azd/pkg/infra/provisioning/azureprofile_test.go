@@ -0,0 +1,81 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseDefaultAzureProfileSubscription_Default(t *testing.T) {
+	path := writeAzureProfile(t, `{
+		"subscriptions": [
+			{"id": "11111111-1111-1111-1111-111111111111", "name": "dev", "tenantId": "t1", "isDefault": false, "environmentName": "AzureCloud"},
+			{"id": "22222222-2222-2222-2222-222222222222", "name": "prod", "tenantId": "t2", "isDefault": true, "environmentName": "AzureUSGovernment"}
+		]
+	}`)
+
+	sub, armEndpointSuffix, ok := parseDefaultAzureProfileSubscription(path)
+	if !ok {
+		t.Fatal("expected a default subscription to be found")
+	}
+	if sub.ID != "22222222-2222-2222-2222-222222222222" {
+		t.Errorf("ID = %q, want the prod subscription", sub.ID)
+	}
+	if armEndpointSuffix != "management.usgovcloudapi.net" {
+		t.Errorf("armEndpointSuffix = %q, want the US Gov ARM endpoint", armEndpointSuffix)
+	}
+}
+
+func TestParseDefaultAzureProfileSubscription_BOM(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "azureProfile.json")
+	contents := "\ufeff" + `{"subscriptions": [{"id": "sub-1", "isDefault": true, "environmentName": "AzureCloud"}]}`
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	sub, _, ok := parseDefaultAzureProfileSubscription(path)
+	if !ok || sub.ID != "sub-1" {
+		t.Fatalf("expected sub-1 to parse despite BOM, got %+v ok=%v", sub, ok)
+	}
+}
+
+func TestParseDefaultAzureProfileSubscription_NoDefault(t *testing.T) {
+	path := writeAzureProfile(t, `{
+		"subscriptions": [
+			{"id": "sub-1", "isDefault": false, "environmentName": "AzureCloud"}
+		]
+	}`)
+
+	_, _, ok := parseDefaultAzureProfileSubscription(path)
+	if ok {
+		t.Fatal("expected ok=false when no subscription is marked default")
+	}
+}
+
+func TestParseDefaultAzureProfileSubscription_MissingFile(t *testing.T) {
+	_, _, ok := parseDefaultAzureProfileSubscription(filepath.Join(t.TempDir(), "does-not-exist.json"))
+	if ok {
+		t.Fatal("expected ok=false for a missing file")
+	}
+}
+
+func TestParseDefaultAzureProfileSubscription_Malformed(t *testing.T) {
+	path := writeAzureProfile(t, `{not valid json`)
+
+	_, _, ok := parseDefaultAzureProfileSubscription(path)
+	if ok {
+		t.Fatal("expected ok=false for malformed JSON")
+	}
+}
+
+func writeAzureProfile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "azureProfile.json")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
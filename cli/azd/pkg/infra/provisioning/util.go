@@ -5,30 +5,124 @@ package provisioning
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/azureutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 )
 
+// EnsureEnvOptions customizes the behavior of EnsureEnv. The zero value preserves the original, fully interactive
+// behavior.
+type EnsureEnvOptions struct {
+	// Subscription, when non-empty, is used in place of prompting for AZURE_SUBSCRIPTION_ID.
+	Subscription string
+	// Location, when non-empty, is used in place of prompting for AZURE_LOCATION.
+	Location string
+	// Tenant, when non-empty, limits the subscriptions offered for selection to those in a specific Azure AD
+	// tenant. Location lookups are not scoped by it.
+	Tenant string
+	// NoPrompt disables all interactive prompts. When a required value is still missing after considering
+	// Subscription, Location and the environment, EnsureEnv returns a *MissingEnvValuesError instead of prompting.
+	NoPrompt bool
+}
+
+// MissingEnvValuesError is returned by EnsureEnv when running with NoPrompt and one or more required values could
+// not be resolved from options or the environment. It implements json.Marshaler so that a caller rendering errors
+// under `--output json` gets structured data instead of having to scrape the prose Error() string.
+type MissingEnvValuesError struct {
+	// Missing is the list of environment variable names that could not be resolved, e.g. "AZURE_SUBSCRIPTION_ID".
+	Missing []string
+}
+
+func (e *MissingEnvValuesError) Error() string {
+	return fmt.Sprintf(
+		"missing required values: %s (provide them via flags or environment variables, or remove --no-prompt)",
+		strings.Join(e.Missing, ", "))
+}
+
+// MarshalJSON renders the error as {"error": "missing_env_values", "missing": [...]}, so a `--output json` caller
+// can branch on which values are missing instead of pattern-matching the human-readable message.
+func (e *MissingEnvValuesError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Error   string   `json:"error"`
+		Missing []string `json:"missing"`
+	}{
+		Error:   "missing_env_values",
+		Missing: e.Missing,
+	})
+}
+
+// NewEnsureEnvOptionsFromFlags builds EnsureEnvOptions from the --subscription/--location/--tenant/--no-prompt
+// flag values a command registers on its flag set. Callers should pass the flag values straight through; empty
+// strings are treated as "not provided" and fall back to prompting (or env/config defaults), same as the
+// EnsureEnvOptions zero value.
+func NewEnsureEnvOptionsFromFlags(subscription, location, tenant string, noPrompt bool) EnsureEnvOptions {
+	return EnsureEnvOptions{
+		Subscription: subscription,
+		Location:     location,
+		Tenant:       tenant,
+		NoPrompt:     noPrompt,
+	}
+}
+
 // EnsureEnv ensures that the environment is in a provision-ready state with required values set, prompting the user if
 // values are unset.
 //
 // This currently means that subscription (AZURE_SUBSCRIPTION_ID) and location (AZURE_LOCATION) variables are set.
+// Pass opts to supply values up front (e.g. from --subscription/--location/--tenant flags) and to control whether
+// EnsureEnv may fall back to interactive prompts when values are still missing.
 func EnsureEnv(
 	ctx context.Context,
 	console input.Console,
 	env *environment.Environment,
-	accountManager account.Manager) error {
+	accountManager account.Manager,
+	opts ...EnsureEnvOptions) error {
+	var opt EnsureEnvOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	if env.GetSubscriptionId() == "" && opt.Subscription != "" {
+		env.SetSubscriptionId(opt.Subscription)
+
+		if err := env.Save(); err != nil {
+			return err
+		}
+	}
+
+	if env.GetLocation() == "" && opt.Location != "" {
+		env.SetLocation(opt.Location)
+
+		if err := env.Save(); err != nil {
+			return err
+		}
+	}
+
+	// Under NoPrompt, opt.Subscription/opt.Location above are the only chance to resolve these values; short-circuit
+	// here with a structured error instead of falling through to the interactive prompts below.
+	if opt.NoPrompt {
+		if missing := missingEnvValues(env.GetSubscriptionId(), env.GetLocation()); len(missing) > 0 {
+			// MissingEnvValuesError implements json.Marshaler, so a caller rendering this error under --output json
+			// gets {"error": "missing_env_values", "missing": [...]} rather than the prose Error() string.
+			return &MissingEnvValuesError{Missing: missing}
+		}
+
+		return nil
+	}
+
 	if env.GetSubscriptionId() == "" {
 		subscriptionId, err := promptSubscription(
 			ctx,
 			"Please select an Azure Subscription to use:",
+			opt.Tenant,
 			console,
 			accountManager)
 		if err != nil {
@@ -64,17 +158,38 @@ func EnsureEnv(
 	return nil
 }
 
+// missingEnvValues reports which of the required environment values are still unresolved, for EnsureEnv's NoPrompt
+// mode. subscriptionId and location are the values already in the environment (after any opt.Subscription/opt.Location
+// fallback has been applied).
+func missingEnvValues(subscriptionId, location string) []string {
+	var missing []string
+
+	if subscriptionId == "" {
+		missing = append(missing, environment.SubscriptionIdEnvVarName)
+	}
+	if location == "" {
+		missing = append(missing, environment.LocationEnvVarName)
+	}
+
+	return missing
+}
+
 func promptSubscription(
 	ctx context.Context,
 	msg string,
+	tenant string,
 	console input.Console,
 	account account.Manager) (subscriptionId string, err error) {
-	subscriptionOptions, defaultSubscription, err := getSubscriptionOptions(ctx, account)
+	subscriptionOptions, defaultSubscription, err := getSubscriptionOptions(ctx, account, tenant, console)
 	if err != nil {
 		return "", err
 	}
 
 	if len(subscriptionOptions) == 0 {
+		if tenant != "" {
+			return "", fmt.Errorf("no subscriptions found in tenant %s", tenant)
+		}
+
 		return "", fmt.Errorf(heredoc.Doc(
 			`no subscriptions found.
 			Ensure you have a subscription by visiting https://portal.azure.com and search for Subscriptions in the search bar.
@@ -128,18 +243,41 @@ func promptLocation(
 	return loc, nil
 }
 
-func getSubscriptionOptions(ctx context.Context, subscriptions account.Manager) ([]string, any, error) {
+// getSubscriptionOptions lists the subscriptions to offer for selection, along with which one should be
+// pre-selected. When tenant is non-empty, only subscriptions homed in that Azure AD tenant are offered.
+func getSubscriptionOptions(
+	ctx context.Context, subscriptions account.Manager, tenant string, console input.Console) ([]string, any, error) {
 	subscriptionInfos, err := subscriptions.GetSubscriptions(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("listing accounts: %w", err)
 	}
 
-	// The default value is based on AZURE_SUBSCRIPTION_ID, falling back to whatever default subscription in
-	// set in azd's config.
+	subscriptionInfos = filterSubscriptionsByTenant(subscriptionInfos, tenant)
+
+	// The default value is based on AZURE_SUBSCRIPTION_ID, falling back to whatever default subscription is
+	// set in azd's config, and finally to the default subscription recorded by `az login` in
+	// ~/.azure/azureProfile.json. azd's own config always wins when set, so this is additive: a machine that has
+	// only ever run `az login` still gets a sensible pre-selected subscription.
 	defaultSubscriptionId := os.Getenv(environment.SubscriptionIdEnvVarName)
 	if defaultSubscriptionId == "" {
 		defaultSubscriptionId = subscriptions.GetDefaultSubscriptionID(ctx)
 	}
+	if defaultSubscriptionId == "" {
+		if azProfileSub, armEndpointSuffix, ok := defaultAzureProfileSubscription(); ok {
+			defaultSubscriptionId = azProfileSub.ID
+
+			// azd itself doesn't switch ARM endpoints based on azureProfile.json, so when az CLI's default
+			// subscription lives in a sovereign cloud, warn rather than silently defaulting to the wrong one.
+			if azProfileSub.EnvironmentName != "" && azProfileSub.EnvironmentName != "AzureCloud" {
+				console.MessageUxItem(ctx, &ux.WarningMessage{
+					Description: fmt.Sprintf(
+						"Your az CLI default subscription is in %s (ARM endpoint: %s). "+
+							"Run 'azd config set cloud.name %s' so azd talks to the same cloud.",
+						azProfileSub.EnvironmentName, armEndpointSuffix, azProfileSub.EnvironmentName),
+				})
+			}
+		}
+	}
 
 	var subscriptionOptions = make([]string, len(subscriptionInfos))
 	var defaultSubscription any
@@ -154,3 +292,20 @@ func getSubscriptionOptions(ctx context.Context, subscriptions account.Manager)
 
 	return subscriptionOptions, defaultSubscription, nil
 }
+
+// filterSubscriptionsByTenant returns the subset of subscriptions homed in tenant. If tenant is empty,
+// subscriptions is returned unchanged.
+func filterSubscriptionsByTenant(subscriptions []account.Subscription, tenant string) []account.Subscription {
+	if tenant == "" {
+		return subscriptions
+	}
+
+	var filtered []account.Subscription
+	for _, info := range subscriptions {
+		if info.TenantId == tenant {
+			filtered = append(filtered, info)
+		}
+	}
+
+	return filtered
+}
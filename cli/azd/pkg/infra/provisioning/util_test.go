@@ -0,0 +1,129 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+)
+
+func TestMissingEnvValuesError_Error(t *testing.T) {
+	err := &MissingEnvValuesError{Missing: []string{"AZURE_SUBSCRIPTION_ID", "AZURE_LOCATION"}}
+
+	got := err.Error()
+	want := "missing required values: AZURE_SUBSCRIPTION_ID, AZURE_LOCATION " +
+		"(provide them via flags or environment variables, or remove --no-prompt)"
+	if got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestMissingEnvValuesError_MarshalJSON(t *testing.T) {
+	err := &MissingEnvValuesError{Missing: []string{"AZURE_SUBSCRIPTION_ID"}}
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Marshal: %v", marshalErr)
+	}
+
+	var decoded struct {
+		Error   string   `json:"error"`
+		Missing []string `json:"missing"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if decoded.Error != "missing_env_values" {
+		t.Errorf("error field = %q, want %q", decoded.Error, "missing_env_values")
+	}
+	if len(decoded.Missing) != 1 || decoded.Missing[0] != "AZURE_SUBSCRIPTION_ID" {
+		t.Errorf("missing field = %v, want [AZURE_SUBSCRIPTION_ID]", decoded.Missing)
+	}
+}
+
+func TestMissingEnvValues_BothMissing(t *testing.T) {
+	missing := missingEnvValues("", "")
+
+	want := []string{environment.SubscriptionIdEnvVarName, environment.LocationEnvVarName}
+	if len(missing) != len(want) || missing[0] != want[0] || missing[1] != want[1] {
+		t.Errorf("missingEnvValues(\"\", \"\") = %v, want %v", missing, want)
+	}
+}
+
+func TestMissingEnvValues_SubscriptionOnly(t *testing.T) {
+	missing := missingEnvValues("", "eastus")
+
+	if len(missing) != 1 || missing[0] != environment.SubscriptionIdEnvVarName {
+		t.Errorf("missingEnvValues(\"\", \"eastus\") = %v, want [%s]", missing, environment.SubscriptionIdEnvVarName)
+	}
+}
+
+func TestMissingEnvValues_LocationOnly(t *testing.T) {
+	missing := missingEnvValues("sub-id", "")
+
+	if len(missing) != 1 || missing[0] != environment.LocationEnvVarName {
+		t.Errorf("missingEnvValues(\"sub-id\", \"\") = %v, want [%s]", missing, environment.LocationEnvVarName)
+	}
+}
+
+func TestMissingEnvValues_NoneMissing(t *testing.T) {
+	missing := missingEnvValues("sub-id", "eastus")
+
+	if len(missing) != 0 {
+		t.Errorf("missingEnvValues(\"sub-id\", \"eastus\") = %v, want none missing", missing)
+	}
+}
+
+func TestFilterSubscriptionsByTenant_NoTenant(t *testing.T) {
+	subscriptions := []account.Subscription{
+		{Id: "sub-1", TenantId: "tenant-a"},
+		{Id: "sub-2", TenantId: "tenant-b"},
+	}
+
+	filtered := filterSubscriptionsByTenant(subscriptions, "")
+	if len(filtered) != 2 {
+		t.Errorf("expected all subscriptions when tenant is empty, got %+v", filtered)
+	}
+}
+
+func TestFilterSubscriptionsByTenant_ExcludesOtherTenants(t *testing.T) {
+	subscriptions := []account.Subscription{
+		{Id: "sub-1", TenantId: "tenant-a"},
+		{Id: "sub-2", TenantId: "tenant-b"},
+	}
+
+	filtered := filterSubscriptionsByTenant(subscriptions, "tenant-b")
+	if len(filtered) != 1 || filtered[0].Id != "sub-2" {
+		t.Fatalf("expected only sub-2 to match tenant-b, got %+v", filtered)
+	}
+}
+
+func TestFilterSubscriptionsByTenant_NoMatches(t *testing.T) {
+	subscriptions := []account.Subscription{
+		{Id: "sub-1", TenantId: "tenant-a"},
+	}
+
+	filtered := filterSubscriptionsByTenant(subscriptions, "tenant-z")
+	if len(filtered) != 0 {
+		t.Errorf("expected no subscriptions to match tenant-z, got %+v", filtered)
+	}
+}
+
+func TestNewEnsureEnvOptionsFromFlags(t *testing.T) {
+	opts := NewEnsureEnvOptionsFromFlags("sub-id", "eastus", "tenant-id", true)
+
+	want := EnsureEnvOptions{
+		Subscription: "sub-id",
+		Location:     "eastus",
+		Tenant:       "tenant-id",
+		NoPrompt:     true,
+	}
+	if opts != want {
+		t.Errorf("NewEnsureEnvOptionsFromFlags() = %+v, want %+v", opts, want)
+	}
+}